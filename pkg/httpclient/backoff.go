@@ -0,0 +1,80 @@
+package httpclient
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BackoffFunc computes how long to wait before the next retry attempt. resp
+// is the response from the previous attempt, which may be nil if the
+// previous attempt failed before a response was received. attempt is
+// 0-indexed: 0 is the wait before the first retry.
+type BackoffFunc func(attempt int, resp *http.Response) time.Duration
+
+// ConstantBackoff returns a BackoffFunc that always waits for the same
+// duration between retries.
+func ConstantBackoff(d time.Duration) BackoffFunc {
+	return func(_ int, _ *http.Response) time.Duration {
+		return d
+	}
+}
+
+// RetryAfterBackoff returns a BackoffFunc that honors the Retry-After header
+// on 429 (Too Many Requests) and 503 (Service Unavailable) responses. The
+// header is parsed as either delta-seconds or an RFC1123 HTTP-date, per
+// https://www.rfc-editor.org/rfc/rfc7231#section-7.1.3. When resp is nil,
+// the status code doesn't warrant it, or the header is missing/unparsable,
+// fallback is used instead.
+func RetryAfterBackoff(fallback BackoffFunc) BackoffFunc {
+	return func(attempt int, resp *http.Response) time.Duration {
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				return d
+			}
+		}
+		return fallback(attempt, resp)
+	}
+}
+
+// parseRetryAfter parses a Retry-After header value as either delta-seconds
+// or an RFC1123 HTTP-date, returning the duration to wait from now.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := time.Parse(time.RFC1123, v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// ExponentialBackoffWithJitter returns a BackoffFunc that doubles the wait
+// time on each attempt starting from min, capped at max, and applies
+// full-jitter randomization (a random duration between 0 and the computed
+// ceiling) so that concurrent clients don't retry in lockstep.
+func ExponentialBackoffWithJitter(min, max time.Duration) BackoffFunc {
+	return func(attempt int, _ *http.Response) time.Duration {
+		ceiling := min << uint(attempt)
+		if ceiling <= 0 || ceiling > max {
+			ceiling = max
+		}
+		if ceiling <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(ceiling)))
+	}
+}
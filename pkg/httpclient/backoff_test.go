@@ -0,0 +1,70 @@
+package httpclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	gmt := time.FixedZone("GMT", 0)
+
+	tests := []struct {
+		name    string
+		header  string
+		wantOK  bool
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{
+			name:    "delta seconds",
+			header:  "120",
+			wantOK:  true,
+			wantMin: 120 * time.Second,
+			wantMax: 120 * time.Second,
+		},
+		{
+			name:    "RFC1123 GMT date in the future",
+			header:  time.Now().In(gmt).Add(2 * time.Hour).Format(time.RFC1123),
+			wantOK:  true,
+			wantMin: 0,
+			wantMax: 2 * time.Hour,
+		},
+		{
+			name:    "RFC1123 GMT date in the past",
+			header:  time.Now().In(gmt).Add(-time.Hour).Format(time.RFC1123),
+			wantOK:  true,
+			wantMin: 0,
+			wantMax: 0,
+		},
+		{
+			name:   "negative delta seconds",
+			header: "-5",
+			wantOK: false,
+		},
+		{
+			name:   "garbage",
+			header: "not-a-date",
+			wantOK: false,
+		},
+		{
+			name:   "empty",
+			header: "",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("parseRetryAfter(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if d < tt.wantMin || d > tt.wantMax {
+				t.Fatalf("parseRetryAfter(%q) = %v, want between %v and %v", tt.header, d, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
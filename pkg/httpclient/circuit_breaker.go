@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrCircuitOpen is returned when a request is short-circuited because its
+// CircuitBreaker is open. RetryableClient.Do treats it as non-retryable,
+// returning it right away without consulting CheckRetry.
+var ErrCircuitOpen = errors.New("httpclient: circuit breaker is open")
+
+// CircuitBreaker isolates callers from an upstream that is failing
+// repeatedly by short-circuiting requests once it trips open, instead of
+// letting every caller pile onto a struggling dependency. Implementations
+// such as sony/gobreaker or slok/goresilience satisfy this interface
+// directly.
+type CircuitBreaker interface {
+	Execute(func() (*http.Response, error)) (*http.Response, error)
+}
+
+// circuitBreakerTransport wraps an http.RoundTripper, routing every
+// request through a CircuitBreaker. If the breaker declines to run the
+// request at all, RoundTrip returns ErrCircuitOpen rather than whatever
+// breaker-specific error the implementation uses internally.
+type circuitBreakerTransport struct {
+	next http.RoundTripper
+	cb   CircuitBreaker
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	called := false
+
+	resp, err := t.cb.Execute(func() (*http.Response, error) {
+		called = true
+		return t.next.RoundTrip(req)
+	})
+	if !called && err != nil {
+		return nil, ErrCircuitOpen
+	}
+
+	return resp, err
+}
+
+func wrapCircuitBreaker(next http.RoundTripper, cb CircuitBreaker) http.RoundTripper {
+	if cb == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &circuitBreakerTransport{next: next, cb: cb}
+}
@@ -0,0 +1,21 @@
+package httpclient
+
+import "context"
+
+type requestRetryOptionsKey struct{}
+
+// WithRequestRetryOptions returns a copy of ctx carrying retry overrides
+// that RetryableClient.Do overlays on top of its own defaults, for the
+// duration of the request built with that context. This lets a single
+// long-lived client apply different retry behavior per call, e.g.
+// aggressive retries for a bulk list and none for a write.
+func WithRequestRetryOptions(ctx context.Context, opts ...OptionRetryable) context.Context {
+	return context.WithValue(ctx, requestRetryOptionsKey{}, opts)
+}
+
+// requestRetryOptionsFrom extracts the per-request retry overrides stashed
+// in ctx by WithRequestRetryOptions, if any.
+func requestRetryOptionsFrom(ctx context.Context) []OptionRetryable {
+	opts, _ := ctx.Value(requestRetryOptionsKey{}).([]OptionRetryable)
+	return opts
+}
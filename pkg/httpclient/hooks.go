@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestHookFunc is invoked immediately before a request attempt is sent.
+// attempt is 0-indexed: 0 is the original attempt, 1 the first retry, etc.
+type RequestHookFunc func(req *http.Request, attempt int)
+
+// ResponseHookFunc is invoked after a request attempt completes, whether it
+// succeeded, failed, or is about to be retried. err is the error (if any)
+// returned by that attempt, and elapsed is how long the attempt took.
+type ResponseHookFunc func(req *http.Request, resp *http.Response, err error, attempt int, elapsed time.Duration)
+
+// hooksTransport wraps an http.RoundTripper, invoking requestHook and
+// responseHook around every RoundTrip call, always reporting attempt 0.
+// Used by New, which has no retry concept of its own; note that the
+// underlying http.Client still calls RoundTrip once per redirect hop, so a
+// single New().Do call can still trigger the hooks more than once.
+// RetryableClient.Do invokes the hooks itself instead, so it can report the
+// real attempt index across retries.
+type hooksTransport struct {
+	next         http.RoundTripper
+	requestHook  RequestHookFunc
+	responseHook ResponseHookFunc
+}
+
+func (t *hooksTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.requestHook != nil {
+		t.requestHook(req, 0)
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	if t.responseHook != nil {
+		t.responseHook(req, resp, err, 0, time.Since(start))
+	}
+
+	return resp, err
+}
+
+func wrapHooks(next http.RoundTripper, reqHook RequestHookFunc, respHook ResponseHookFunc) http.RoundTripper {
+	if reqHook == nil && respHook == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &hooksTransport{next: next, requestHook: reqHook, responseHook: respHook}
+}
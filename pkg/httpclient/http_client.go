@@ -12,13 +12,23 @@ type Requester interface {
 }
 
 type clientOptions struct {
-	Timeout time.Duration
+	Timeout             time.Duration
+	CircuitBreaker      CircuitBreaker
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	TLSHandshakeTimeout time.Duration
+	DisableKeepAlives   bool
+	RequestHook         RequestHookFunc
+	ResponseHook        ResponseHookFunc
 }
 
 type retryOptions struct {
 	clientOptions
-	BackoffStrategy BackoffFunc
-	CheckRetry      CheckRetryFunc
+	RetryMax          int
+	BackoffStrategy   BackoffFunc
+	CheckRetry        CheckRetryFunc
+	RespReadLimit     int64
+	IdempotencyPolicy IdempotencyPolicyFunc
 }
 
 // Option signature for client configurable parameters.
@@ -55,6 +65,69 @@ func WithTimeout(t time.Duration) Option {
 	})
 }
 
+// WithCircuitBreaker routes every request through cb, short-circuiting with
+// ErrCircuitOpen whenever it's open instead of hitting the network. Works
+// with both New and NewRetryable; RetryableClient.Do treats ErrCircuitOpen
+// as non-retryable.
+func WithCircuitBreaker(cb CircuitBreaker) Option {
+	return optFunc(func(options *clientOptions) {
+		options.CircuitBreaker = cb
+	})
+}
+
+// WithMaxIdleConnsPerHost controls the maximum number of idle (keep-alive)
+// connections kept per host in the client's transport.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return optFunc(func(options *clientOptions) {
+		options.MaxIdleConnsPerHost = n
+	})
+}
+
+// WithIdleConnTimeout controls how long an idle (keep-alive) connection in
+// the client's transport is kept around before being closed.
+func WithIdleConnTimeout(d time.Duration) Option {
+	return optFunc(func(options *clientOptions) {
+		options.IdleConnTimeout = d
+	})
+}
+
+// WithTLSHandshakeTimeout controls how long the client's transport waits
+// for a TLS handshake to complete.
+func WithTLSHandshakeTimeout(d time.Duration) Option {
+	return optFunc(func(options *clientOptions) {
+		options.TLSHandshakeTimeout = d
+	})
+}
+
+// WithDisableKeepAlives controls whether the client's transport reuses TCP
+// connections across requests to the same host.
+func WithDisableKeepAlives(disable bool) Option {
+	return optFunc(func(options *clientOptions) {
+		options.DisableKeepAlives = disable
+	})
+}
+
+// WithRequestHook registers hook to be called right before every request
+// attempt is sent. Works with both New (attempt is always 0; note the
+// underlying transport still calls it once per redirect hop) and
+// NewRetryable (called once per attempt, with the attempt index).
+func WithRequestHook(hook RequestHookFunc) Option {
+	return optFunc(func(options *clientOptions) {
+		options.RequestHook = hook
+	})
+}
+
+// WithResponseHook registers hook to be called after every request attempt
+// completes, including attempts that are about to be retried, so callers
+// can emit per-attempt metrics, logs, or traces. Works with both New
+// (attempt is always 0; note the underlying transport still calls it once
+// per redirect hop) and NewRetryable (called once per attempt).
+func WithResponseHook(hook ResponseHookFunc) Option {
+	return optFunc(func(options *clientOptions) {
+		options.ResponseHook = hook
+	})
+}
+
 // WithBackoffStrategy controls the wait time between requests when retrying.
 func WithBackoffStrategy(strategy BackoffFunc) OptionRetryable {
 	return retryableOptFunc(func(options *retryOptions) {
@@ -62,6 +135,15 @@ func WithBackoffStrategy(strategy BackoffFunc) OptionRetryable {
 	})
 }
 
+// WithRetryMax controls the maximum number of retries to execute. Mostly
+// useful through WithRequestRetryOptions, to override a client's default
+// RetryMax for a single request.
+func WithRetryMax(retryMax int) OptionRetryable {
+	return retryableOptFunc(func(options *retryOptions) {
+		options.RetryMax = retryMax
+	})
+}
+
 // WithRetryPolicy controls the retry policy of the given HTTP client.
 func WithRetryPolicy(checkRetry CheckRetryFunc) OptionRetryable {
 	return retryableOptFunc(func(options *retryOptions) {
@@ -69,6 +151,25 @@ func WithRetryPolicy(checkRetry CheckRetryFunc) OptionRetryable {
 	})
 }
 
+// WithRespReadLimit controls how many bytes of a superseded response body
+// are drained before it's closed ahead of a retry. Defaults to
+// RespReadLimit.
+func WithRespReadLimit(limit int64) OptionRetryable {
+	return retryableOptFunc(func(options *retryOptions) {
+		options.RespReadLimit = limit
+	})
+}
+
+// WithIdempotencyPolicy controls which requests are allowed to be retried.
+// When policy returns false for a given request, it will not be retried
+// even if the client's CheckRetry says otherwise. Defaults to
+// DefaultIdempotencyPolicy.
+func WithIdempotencyPolicy(policy IdempotencyPolicyFunc) OptionRetryable {
+	return retryableOptFunc(func(options *retryOptions) {
+		options.IdempotencyPolicy = policy
+	})
+}
+
 var (
 	// DefaultTimeout is the timeout used by default when building a Client.
 	DefaultTimeout = 10 * time.Second
@@ -82,6 +183,31 @@ var (
 	DefaultRetryPolicy = ServerErrorsRetryPolicy()
 )
 
+// buildTransport assembles the http.RoundTripper for a client from config,
+// cloning http.DefaultTransport's settings only when a transport-level
+// option was actually set, then wrapping the result with the circuit
+// breaker if one was configured.
+func buildTransport(config clientOptions) http.RoundTripper {
+	var rt http.RoundTripper = http.DefaultTransport
+
+	if config.MaxIdleConnsPerHost != 0 || config.IdleConnTimeout != 0 || config.TLSHandshakeTimeout != 0 || config.DisableKeepAlives {
+		t := http.DefaultTransport.(*http.Transport).Clone()
+		if config.MaxIdleConnsPerHost != 0 {
+			t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+		}
+		if config.IdleConnTimeout != 0 {
+			t.IdleConnTimeout = config.IdleConnTimeout
+		}
+		if config.TLSHandshakeTimeout != 0 {
+			t.TLSHandshakeTimeout = config.TLSHandshakeTimeout
+		}
+		t.DisableKeepAlives = config.DisableKeepAlives
+		rt = t
+	}
+
+	return wrapCircuitBreaker(rt, config.CircuitBreaker)
+}
+
 // New builds a *http.Client which keeps TCP connections to destination servers.
 //
 // Returned client can be customized by passing options to New.
@@ -95,8 +221,31 @@ func New(opts ...Option) *http.Client {
 	}
 
 	return &http.Client{
-		Timeout: config.Timeout,
+		Timeout:   config.Timeout,
+		Transport: wrapHooks(buildTransport(config), config.RequestHook, config.ResponseHook),
+	}
+}
+
+// NewForSingleHost builds a *http.Client tuned for repeatedly calling the
+// same backend: a large per-host idle connection pool and keep-alives left
+// on, so connections are reused across requests instead of renegotiated
+// each time.
+func NewForSingleHost(opts ...Option) *http.Client {
+	preset := []Option{
+		WithMaxIdleConnsPerHost(64),
+		WithIdleConnTimeout(10 * time.Minute),
+	}
+	return New(append(preset, opts...)...)
+}
+
+// NewForSpraying builds a *http.Client tuned for calling many different
+// hosts: keep-alives are disabled so idle connections aren't held open to
+// hosts that are unlikely to be revisited.
+func NewForSpraying(opts ...Option) *http.Client {
+	preset := []Option{
+		WithDisableKeepAlives(true),
 	}
+	return New(append(preset, opts...)...)
 }
 
 // NewRetryable builds a *RetryableClient which keeps TCP connections to
@@ -111,8 +260,10 @@ func New(opts ...Option) *http.Client {
 // building a *http.Client with New.
 func NewRetryable(retryMax int, opts ...OptionRetryable) *RetryableClient {
 	config := retryOptions{
-		BackoffStrategy: DefaultBackoffStrategy,
-		CheckRetry:      DefaultRetryPolicy,
+		RetryMax:          retryMax,
+		BackoffStrategy:   DefaultBackoffStrategy,
+		CheckRetry:        DefaultRetryPolicy,
+		IdempotencyPolicy: DefaultIdempotencyPolicy(),
 		clientOptions: clientOptions{
 			Timeout: DefaultTimeout,
 		},
@@ -123,11 +274,16 @@ func NewRetryable(retryMax int, opts ...OptionRetryable) *RetryableClient {
 	}
 
 	return &RetryableClient{
-		RetryMax:        retryMax,
-		BackoffStrategy: config.BackoffStrategy,
-		CheckRetry:      config.CheckRetry,
+		RetryMax:          config.RetryMax,
+		BackoffStrategy:   config.BackoffStrategy,
+		CheckRetry:        config.CheckRetry,
+		RespReadLimit:     config.RespReadLimit,
+		IdempotencyPolicy: config.IdempotencyPolicy,
+		RequestHook:       config.RequestHook,
+		ResponseHook:      config.ResponseHook,
 		Client: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: buildTransport(config.clientOptions),
 		},
 	}
 }
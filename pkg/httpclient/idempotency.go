@@ -0,0 +1,25 @@
+package httpclient
+
+import "net/http"
+
+// IdempotencyPolicyFunc reports whether req is safe to retry, i.e. whether
+// resending it cannot cause an unintended duplicate side effect.
+type IdempotencyPolicyFunc func(req *http.Request) bool
+
+// DefaultIdempotencyPolicy returns true for methods that are idempotent by
+// definition (GET, HEAD, OPTIONS, PUT, DELETE) or for any request carrying
+// an Idempotency-Key header, regardless of method.
+func DefaultIdempotencyPolicy() IdempotencyPolicyFunc {
+	return func(req *http.Request) bool {
+		if req.Header.Get("Idempotency-Key") != "" {
+			return true
+		}
+
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// CheckRetryFunc decides whether a request should be retried, given the
+// response (if any) and error from the previous attempt. Returning a
+// non-nil error aborts the retry loop immediately, surfacing that error to
+// the caller in place of err; resp from that attempt is still returned.
+type CheckRetryFunc func(ctx context.Context, resp *http.Response, err error) (bool, error)
+
+// ServerErrorsRetryPolicy returns a CheckRetryFunc that retries on
+// connection errors and 5xx responses.
+func ServerErrorsRetryPolicy() CheckRetryFunc {
+	return func(_ context.Context, resp *http.Response, err error) (bool, error) {
+		if err != nil {
+			return true, nil
+		}
+		if resp != nil && resp.StatusCode >= http.StatusInternalServerError {
+			return true, nil
+		}
+		return false, nil
+	}
+}
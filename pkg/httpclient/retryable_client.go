@@ -0,0 +1,130 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RespReadLimit is the default number of bytes drained from a superseded
+// response body before closing it, so the underlying TCP connection can be
+// reused instead of leaked.
+var RespReadLimit int64 = 4 * 1024
+
+// RetryableClient wraps an *http.Client, retrying requests according to
+// CheckRetry and waiting BackoffStrategy between attempts.
+type RetryableClient struct {
+	Client          *http.Client
+	RetryMax        int
+	BackoffStrategy BackoffFunc
+	CheckRetry      CheckRetryFunc
+
+	// RespReadLimit caps how many bytes of a superseded response body are
+	// drained before it's closed. A value of 0 means RespReadLimit (the
+	// package-level default) is used.
+	RespReadLimit int64
+
+	// IdempotencyPolicy decides whether a given request is safe to retry.
+	// When it returns false, Do will not retry even if CheckRetry would
+	// otherwise say to. A nil policy allows every retry CheckRetry permits.
+	IdempotencyPolicy IdempotencyPolicyFunc
+
+	// RequestHook, if set, is called before every attempt, with the attempt
+	// index.
+	RequestHook RequestHookFunc
+
+	// ResponseHook, if set, is called after every attempt, with the attempt
+	// index, even when that attempt is about to be retried.
+	ResponseHook ResponseHookFunc
+}
+
+// Do executes req, retrying up to RetryMax times according to CheckRetry.
+// Before each retry attempt, req's body is re-materialized from scratch so
+// POST/PUT/PATCH requests can be safely resent.
+//
+// Retry behavior configured on req's context via WithRequestRetryOptions
+// overlays the client's own defaults for the duration of this call only.
+func (c *RetryableClient) Do(req *RetryableRequest) (*http.Response, error) {
+	config := retryOptions{
+		RetryMax:          c.RetryMax,
+		BackoffStrategy:   c.BackoffStrategy,
+		CheckRetry:        c.CheckRetry,
+		RespReadLimit:     c.RespReadLimit,
+		IdempotencyPolicy: c.IdempotencyPolicy,
+		clientOptions: clientOptions{
+			RequestHook:  c.RequestHook,
+			ResponseHook: c.ResponseHook,
+		},
+	}
+	for _, opt := range requestRetryOptionsFrom(req.Context()) {
+		opt.applyRetryable(&config)
+	}
+
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if rewindErr := req.rewind(); rewindErr != nil {
+				return nil, rewindErr
+			}
+		}
+
+		if resp != nil {
+			drainBody(resp, respReadLimit(config.RespReadLimit))
+		}
+
+		if config.RequestHook != nil {
+			config.RequestHook(req.Request, attempt)
+		}
+
+		start := time.Now()
+		resp, err = c.Client.Do(req.Request)
+		elapsed := time.Since(start)
+
+		if config.ResponseHook != nil {
+			config.ResponseHook(req.Request, resp, err, attempt, elapsed)
+		}
+
+		if errors.Is(err, ErrCircuitOpen) {
+			return resp, err
+		}
+
+		shouldRetry, checkErr := config.CheckRetry(req.Context(), resp, err)
+		if checkErr != nil {
+			return resp, checkErr
+		}
+		if !shouldRetry || attempt >= config.RetryMax {
+			return resp, err
+		}
+		if config.IdempotencyPolicy != nil && !config.IdempotencyPolicy(req.Request) {
+			return resp, err
+		}
+
+		wait := config.BackoffStrategy(attempt, resp)
+		select {
+		case <-req.Context().Done():
+			return resp, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+func respReadLimit(limit int64) int64 {
+	if limit > 0 {
+		return limit
+	}
+	return RespReadLimit
+}
+
+// drainBody reads up to limit bytes of resp's body and closes it, allowing
+// the underlying connection to be reused by the transport's connection
+// pool instead of being discarded.
+func drainBody(resp *http.Response, limit int64) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, limit))
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,158 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RetryableRequest wraps an *http.Request with a way to re-materialize its
+// body ahead of each attempt, since http.Request.Body is a read-once
+// io.ReadCloser and the second attempt of a naive retry would otherwise
+// send an empty body.
+type RetryableRequest struct {
+	*http.Request
+
+	getBody func() (io.Reader, error)
+}
+
+// NewRetryableRequest builds a RetryableRequest for the given method and
+// url. body may be nil, []byte, string, *bytes.Buffer, io.ReadSeeker, or
+// func() (io.Reader, error); any other type returns an error.
+//
+// The request's Content-Length and GetBody are set whenever the body's
+// size can be determined upfront, so outgoing requests aren't forced into
+// chunked transfer-encoding and stdlib-driven 307/308 redirects can also
+// replay the body.
+func NewRetryableRequest(method, url string, body interface{}) (*RetryableRequest, error) {
+	getBody, contentLength, err := bodyReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := &RetryableRequest{Request: req, getBody: getBody}
+
+	if getBody != nil {
+		rr.ContentLength = contentLength
+		rr.GetBody = func() (io.ReadCloser, error) {
+			reader, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return toReadCloser(reader), nil
+		}
+	}
+
+	if err := rr.rewind(); err != nil {
+		return nil, err
+	}
+
+	return rr, nil
+}
+
+// NewRetryableRequestWithContext is like NewRetryableRequest, but uses ctx
+// for the underlying request instead of context.Background(). This is the
+// constructor to use with WithRequestRetryOptions, since RetryableClient.Do
+// reads per-request retry overrides from the request's context.
+func NewRetryableRequestWithContext(ctx context.Context, method, url string, body interface{}) (*RetryableRequest, error) {
+	rr, err := NewRetryableRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return rr.WithContext(ctx), nil
+}
+
+// WithContext returns a shallow copy of r with its context changed to ctx,
+// the same as http.Request.WithContext. Unlike calling WithContext on the
+// embedded *http.Request directly, this preserves getBody so the copy can
+// still rewind its body across retries.
+func (r *RetryableRequest) WithContext(ctx context.Context) *RetryableRequest {
+	clone := *r
+	clone.Request = r.Request.WithContext(ctx)
+	return &clone
+}
+
+// rewind re-materializes the request body ahead of an attempt.
+func (r *RetryableRequest) rewind() error {
+	if r.getBody == nil {
+		return nil
+	}
+
+	reader, err := r.getBody()
+	if err != nil {
+		return fmt.Errorf("httpclient: rewinding request body: %w", err)
+	}
+
+	r.Body = toReadCloser(reader)
+
+	return nil
+}
+
+func toReadCloser(r io.Reader) io.ReadCloser {
+	if rc, ok := r.(io.ReadCloser); ok {
+		return rc
+	}
+	return io.NopCloser(r)
+}
+
+// bodyReader turns the supported body types into a factory that can be
+// called again on every retry attempt to get a fresh io.Reader positioned
+// at the start of the body, along with the body's total size (-1 if it
+// can't be determined upfront, as is the case for func() (io.Reader, error)).
+func bodyReader(body interface{}) (func() (io.Reader, error), int64, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, 0, nil
+	case func() (io.Reader, error):
+		return b, -1, nil
+	case []byte:
+		return func() (io.Reader, error) { return bytes.NewReader(b), nil }, int64(len(b)), nil
+	case string:
+		return func() (io.Reader, error) { return strings.NewReader(b), nil }, int64(len(b)), nil
+	case *bytes.Buffer:
+		buf := b.Bytes()
+		return func() (io.Reader, error) { return bytes.NewReader(buf), nil }, int64(len(buf)), nil
+	case io.ReadSeeker:
+		length, err := seekerLength(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		return func() (io.Reader, error) {
+			if _, err := b.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("httpclient: seeking request body: %w", err)
+			}
+			return b, nil
+		}, length, nil
+	default:
+		return nil, 0, fmt.Errorf("httpclient: unsupported request body type %T", body)
+	}
+}
+
+// seekerLength measures the total size of s, from its start to its end,
+// without consuming it, leaving s positioned exactly where it found it.
+func seekerLength(s io.ReadSeeker) (int64, error) {
+	cur, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, fmt.Errorf("httpclient: measuring request body length: %w", err)
+	}
+
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("httpclient: measuring request body length: %w", err)
+	}
+
+	if _, err := s.Seek(cur, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("httpclient: measuring request body length: %w", err)
+	}
+
+	return end, nil
+}
@@ -0,0 +1,40 @@
+package httpclient
+
+import (
+	"io"
+	"testing"
+)
+
+func TestNewRetryableRequestBodyIsReusableAcrossRewinds(t *testing.T) {
+	rr, err := NewRetryableRequest("POST", "http://example.com", []byte("payload"))
+	if err != nil {
+		t.Fatalf("NewRetryableRequest() error = %v", err)
+	}
+
+	if rr.ContentLength != int64(len("payload")) {
+		t.Fatalf("ContentLength = %d, want %d", rr.ContentLength, len("payload"))
+	}
+	if rr.GetBody == nil {
+		t.Fatal("GetBody is nil, want a non-nil getter so stdlib redirects can replay the body")
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		got, err := io.ReadAll(rr.Body)
+		if err != nil {
+			t.Fatalf("attempt %d: reading body: %v", attempt, err)
+		}
+		if string(got) != "payload" {
+			t.Fatalf("attempt %d: body = %q, want %q", attempt, got, "payload")
+		}
+
+		if err := rr.rewind(); err != nil {
+			t.Fatalf("attempt %d: rewind() error = %v", attempt, err)
+		}
+	}
+}
+
+func TestNewRetryableRequestRejectsUnsupportedBody(t *testing.T) {
+	if _, err := NewRetryableRequest("POST", "http://example.com", 42); err == nil {
+		t.Fatal("NewRetryableRequest() with an unsupported body type, want an error")
+	}
+}
@@ -0,0 +1,14 @@
+package invoice
+
+import (
+	"net/http"
+
+	"github.com/marvin-w/sdk-go/pkg/httpclient"
+)
+
+// NewHTTPClient builds the *http.Client used to talk to the invoice
+// backend. The invoice API is a single host hit repeatedly, so it's built
+// on top of httpclient.NewForSingleHost rather than httpclient.New.
+func NewHTTPClient(opts ...httpclient.Option) *http.Client {
+	return httpclient.NewForSingleHost(opts...)
+}
@@ -0,0 +1,8 @@
+package invoice
+
+// Invoice represents a single invoice returned by the search endpoint.
+type Invoice struct {
+	ID     string
+	Status string
+	Amount int64 // amount in the smallest unit of the invoice's currency
+}
@@ -0,0 +1,91 @@
+package invoice
+
+import (
+	"context"
+	"io"
+)
+
+// FetchFunc retrieves one page of results for req, returning the items on
+// that page and the total number of items across all pages. Implementations
+// that receive a cursor from the backend may write it back to
+// req.PageToken to upgrade the SearchRequest to cursor-based pagination.
+type FetchFunc func(ctx context.Context, req *SearchRequest) (items []Invoice, total int, err error)
+
+// Iterator walks every Invoice matching a SearchRequest, fetching
+// additional pages via its FetchFunc as the in-memory buffer empties.
+type Iterator struct {
+	ctx   context.Context
+	req   *SearchRequest
+	fetch FetchFunc
+
+	buf     []Invoice
+	fetched int
+	done    bool
+}
+
+// NewIterator builds an Iterator that walks req's results, fetching pages
+// with fetch.
+func NewIterator(ctx context.Context, req *SearchRequest, fetch FetchFunc) *Iterator {
+	return &Iterator{ctx: ctx, req: req, fetch: fetch}
+}
+
+// Next returns the next Invoice, fetching another page first if the
+// in-memory buffer is empty. It returns io.EOF once every page has been
+// consumed.
+func (it *Iterator) Next() (Invoice, error) {
+	for len(it.buf) == 0 {
+		if it.done {
+			return Invoice{}, io.EOF
+		}
+		if err := it.fetchPage(); err != nil {
+			return Invoice{}, err
+		}
+	}
+
+	item := it.buf[0]
+	it.buf = it.buf[1:]
+
+	return item, nil
+}
+
+// Pages returns one page of Invoice at a time, fetching it if needed, and
+// nil once every page has been consumed.
+func (it *Iterator) Pages() ([]Invoice, error) {
+	if len(it.buf) == 0 {
+		if it.done {
+			return nil, nil
+		}
+		if err := it.fetchPage(); err != nil {
+			return nil, err
+		}
+	}
+
+	page := it.buf
+	it.buf = nil
+
+	return page, nil
+}
+
+// fetchPage requests the next page and advances Offset by the number of
+// items actually returned, so a short-but-not-final page doesn't skip any
+// items. Cursor-based requests (PageToken already set) are left alone;
+// fetch itself is expected to update req.PageToken from the response.
+func (it *Iterator) fetchPage() error {
+	items, total, err := it.fetch(it.ctx, it.req)
+	if err != nil {
+		return err
+	}
+
+	it.buf = append(it.buf, items...)
+	it.fetched += len(items)
+
+	if it.req.PageToken == "" {
+		it.req.Offset += len(items)
+	}
+
+	if len(items) == 0 || it.fetched >= total {
+		it.done = true
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package invoice
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+func TestIteratorNextEOF(t *testing.T) {
+	pages := [][]Invoice{
+		{{ID: "1"}, {ID: "2"}},
+		{{ID: "3"}},
+	}
+	const total = 3
+	call := 0
+
+	fetch := func(_ context.Context, _ *SearchRequest) ([]Invoice, int, error) {
+		if call >= len(pages) {
+			return nil, total, nil
+		}
+		page := pages[call]
+		call++
+		return page, total, nil
+	}
+
+	it := NewIterator(context.Background(), &SearchRequest{}, fetch)
+
+	var got []string
+	for {
+		inv, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, inv.ID)
+	}
+
+	want := []string{"1", "2", "3"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if _, err := it.Next(); err != io.EOF {
+		t.Fatalf("Next() after exhaustion = %v, want io.EOF", err)
+	}
+}
+
+func TestIteratorOffsetAdvancesByItemsReturned(t *testing.T) {
+	var offsets []int
+
+	fetch := func(_ context.Context, req *SearchRequest) ([]Invoice, int, error) {
+		offsets = append(offsets, req.Offset)
+		switch req.Offset {
+		case 0:
+			// Short page: caller asked for more, backend returned fewer.
+			return []Invoice{{ID: "1"}, {ID: "2"}}, 3, nil
+		case 2:
+			return []Invoice{{ID: "3"}}, 3, nil
+		default:
+			return nil, 3, nil
+		}
+	}
+
+	it := NewIterator(context.Background(), &SearchRequest{Limit: 10}, fetch)
+	for {
+		if _, err := it.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+	}
+
+	want := []int{0, 2}
+	if len(offsets) != len(want) {
+		t.Fatalf("fetch called with offsets %v, want %v", offsets, want)
+	}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Fatalf("offsets[%d] = %d, want %d", i, offsets[i], want[i])
+		}
+	}
+}
@@ -10,6 +10,12 @@ type SearchRequest struct {
 	Limit   int               // limit of items returned
 	Offset  int               // first item to be shown
 	Filters map[string]string // other filters (details in the link above)
+
+	// PageToken, when set, lets the backend return a cursor to continue
+	// from instead of Offset. It's ignored by GetParams today, but an
+	// Iterator's fetch callback can use it to upgrade a SearchRequest to
+	// cursor-based pagination without breaking offset-based callers.
+	PageToken string
 }
 
 // GetParams creates map to build query parameters. Keys will be changed to lower case.